@@ -5,6 +5,8 @@ import (
 	"math"
 	"fmt"
 	"errors"
+
+	"github.com/evilbros/goNEAT/neat/network/loss"
 )
 
 var (
@@ -12,6 +14,8 @@ var (
 	NetErrExceededMaxActivationAttempts = errors.New("maximal network activation attempts exceeded.")
 	// The error to be raised when unsupported sensors data array size provided
 	NetErrUnsupportedSensorsArraySize = errors.New("the sensors array size is unsupported by network solver")
+	// The error to be raised when a non-positive number of activation steps is requested
+	NetErrInvalidActivationStepsNumber = errors.New("the number of activation steps must be positive")
 )
 
 // Defines network solver interface which describes neural network structures with methods to run activation waves through
@@ -25,6 +29,16 @@ type NetworkSolver interface {
 	// Returns true if activation wave passed from all inputs to outputs.
 	RecursiveSteps() (bool, error)
 
+	// Repeatedly runs a single forward activation pass as a pure function of the currently loaded sensor values,
+	// unlike ForwardSteps/RecursiveSteps whose effect on any given call depends on whatever activation state was
+	// left over from previous calls. It first flushes the network, then on each pass computes every node's new
+	// value into a shadow buffer that only becomes visible to downstream consumers once the whole pass completes,
+	// and stops as soon as every output node is active and the largest |delta| of any output across a pass falls
+	// below a small epsilon, or after maxSteps passes. Returns false if the network failed to stabilize in time.
+	// This is what makes CPPN queries deterministic and independent of previous inputs, which HyperNEAT-style
+	// substrate queries rely on.
+	SnapshotSteps(maxSteps int) (bool, error)
+
 	// Attempts to relax network given amount of steps until giving up. The network considered relaxed when absolute
 	// value of the change at any given point is less than maxAllowedSignalDelta during activation waves propagation.
 	// If maxAllowedSignalDelta value is less than or equal to 0, the method will return true without checking for relaxation.
@@ -43,6 +57,37 @@ type NetworkSolver interface {
 	NodeCount() int
 	// Returns the total number of links between nodes in the network
 	LinkCount() int
+
+	// The batched (data-parallel) activation API below lets a single network be evaluated against N input patterns
+	// at once, propagating all of them side by side instead of looping and re-flushing the scalar methods above
+	// between patterns - this is what pays off when an experiment runs the same genome over hundreds of trials.
+	// The scalar methods above are equivalent to calling these with a single lane.
+
+	// Loads N sensor patterns at once, one per lane, for a subsequent ForwardStepsBatch/RecursiveStepsBatch call.
+	LoadSensorsBatch(inputs [][]float64) error
+	// Propagates activation wave through all network nodes for every lane loaded by LoadSensorsBatch, given number
+	// of steps in forward direction. Returns true if activation wave passed from all inputs to outputs in every lane.
+	ForwardStepsBatch(steps int) (bool, error)
+	// Propagates activation wave through all network nodes for every lane loaded by LoadSensorsBatch by recursion
+	// from output nodes. Returns true if activation wave passed from all inputs to outputs in every lane.
+	RecursiveStepsBatch() (bool, error)
+	// Reads output values from the output nodes of the network for every lane, in the same order as the patterns
+	// passed to LoadSensorsBatch.
+	ReadOutputsBatch() [][]float64
+
+	// Returns true if given lane still holds its initial (flushed) state, i.e. no activation has reached it yet.
+	Fresh(lane int) bool
+	// Flushes network state for the given lane only, leaving the other lanes untouched. Returns true if the lane
+	// flushed successfully or false in case of error. Useful to reset individual episodes of a batch without
+	// re-loading the whole lane set, e.g. RL rollouts of varying length.
+	FlushLane(lane int) (bool, error)
+
+	// Runs the network once per input/target pair - or once per lane, when the batched methods above are used -
+	// and reduces the configured loss function over all samples via arithmetic mean. Lets a caller, typically an
+	// experiment's fitness function, score a network's predictions against known targets with a consistent notion
+	// of error instead of hand-rolling its own, and gives population-level utilities a common "test loss" to log
+	// alongside raw fitness.
+	EvaluateLoss(inputs, targets [][]float64, lossFn loss.LossFunction) (float64, error)
 }
 
 // NNodeType defines the type of NNode to create
@@ -147,6 +192,18 @@ const (
 	MultiplyModuleActivation
 	MaxModuleActivation
 	MinModuleActivation
+	SoftmaxModuleActivation
+
+	// The modern activators commonly found in today's neuro-inspired frameworks
+	ReLUActivation
+	LeakyReLUActivation
+	ELUActivation
+	SELUActivation
+	SoftplusActivation
+	SoftsignActivation
+	SwishActivation
+	MishActivation
+	LogSigmoidActivation
 )
 
 // The neuron node activation function type
@@ -199,10 +256,21 @@ func NewNodeActivatorsFactory() *NodeActivatorsFactory {
 	af.Register(SineActivation, sineFunction, "SineActivation")
 	af.Register(StepActivation, stepFunction, "StepActivation")
 
+	af.Register(ReLUActivation, relu, "ReLUActivation")
+	af.Register(LeakyReLUActivation, leakyRelu, "LeakyReLUActivation")
+	af.Register(ELUActivation, elu, "ELUActivation")
+	af.Register(SELUActivation, selu, "SELUActivation")
+	af.Register(SoftplusActivation, softplus, "SoftplusActivation")
+	af.Register(SoftsignActivation, softsign, "SoftsignActivation")
+	af.Register(SwishActivation, swish, "SwishActivation")
+	af.Register(MishActivation, mish, "MishActivation")
+	af.Register(LogSigmoidActivation, logSigmoid, "LogSigmoidActivation")
+
 	// register neuron modules activators
 	af.RegisterModule(MultiplyModuleActivation, multiplyModule, "MultiplyModuleActivation")
 	af.RegisterModule(MaxModuleActivation, maxModule, "MaxModuleActivation")
 	af.RegisterModule(MinModuleActivation, minModule, "MinModuleActivation")
+	af.RegisterModule(SoftmaxModuleActivation, softmaxModule, "SoftmaxModuleActivation")
 
 	return af
 }
@@ -229,7 +297,9 @@ func (a *NodeActivatorsFactory) ActivateByType(input float64, aux_params[]float6
 
 // Method to activate neuron module presented by provided node. As a result of execution the activation values of all
 // input nodes will be processed by corresponding activation function and corresponding activation values of output nodes
-// will be set. Will panic if unsupported activation type requested.
+// will be set. Will return error if unsupported activation type requested. Note that for SoftmaxModuleActivation the number
+// of module's Outgoing links must match the number of Incoming links, since the activator produces exactly one
+// output value per input.
 func (a *NodeActivatorsFactory) ActivateModule(module *NNode) error {
 	inputs := make([]float64, len(module.Incoming))
 	for i, v := range module.Incoming {
@@ -417,6 +487,76 @@ var (
 	}
 )
 
+// The modern activation functions commonly found in deep learning frameworks
+var (
+	// The rectified linear unit: max(0, x)
+	relu = func(input float64, aux_params[]float64) float64 {
+		return math.Max(0.0, input)
+	}
+	// The leaky ReLU: x if x > 0 else alpha * x. The alpha coefficient is read from aux_params[0], defaulting to 0.01
+	leakyRelu = func(input float64, aux_params[]float64) float64 {
+		alpha := 0.01
+		if len(aux_params) > 0 {
+			alpha = aux_params[0]
+		}
+		if input > 0.0 {
+			return input
+		}
+		return alpha * input
+	}
+	// The exponential linear unit: x if x >= 0 else alpha * (exp(x) - 1). The alpha coefficient is read from
+	// aux_params[0], defaulting to 1.0
+	elu = func(input float64, aux_params[]float64) float64 {
+		alpha := 1.0
+		if len(aux_params) > 0 {
+			alpha = aux_params[0]
+		}
+		return eluValue(input, alpha)
+	}
+	// The scaled exponential linear unit: lambda * ELU(x, alpha), using the standard self-normalizing constants
+	selu = func(input float64, aux_params[]float64) float64 {
+		const lambda, alpha = 1.0507, 1.6733
+		return lambda * eluValue(input, alpha)
+	}
+	// The softplus: log(1 + exp(x)), computed via math.Log1p(math.Exp(-|x|)) + max(x, 0) for numerical stability
+	softplus = func(input float64, aux_params[]float64) float64 {
+		return softplusValue(input)
+	}
+	// The softsign: x / (1 + |x|)
+	softsign = func(input float64, aux_params[]float64) float64 {
+		return input / (1.0 + math.Abs(input))
+	}
+	// The swish: x * sigmoid(beta * x). The beta coefficient is read from aux_params[0], defaulting to 1.0
+	swish = func(input float64, aux_params[]float64) float64 {
+		beta := 1.0
+		if len(aux_params) > 0 {
+			beta = aux_params[0]
+		}
+		return input / (1.0 + math.Exp(-beta*input))
+	}
+	// The mish: x * tanh(softplus(x))
+	mish = func(input float64, aux_params[]float64) float64 {
+		return input * math.Tanh(softplusValue(input))
+	}
+	// The log-sigmoid: -softplus(-x)
+	logSigmoid = func(input float64, aux_params[]float64) float64 {
+		return -softplusValue(-input)
+	}
+)
+
+// Computes the exponential linear unit value for the given alpha coefficient
+func eluValue(input, alpha float64) float64 {
+	if input >= 0.0 {
+		return input
+	}
+	return alpha * (math.Exp(input) - 1.0)
+}
+
+// Computes the softplus value in its numerically stable form
+func softplusValue(input float64) float64 {
+	return math.Log1p(math.Exp(-math.Abs(input))) + math.Max(input, 0.0)
+}
+
 // The modular activators
 var (
 	// Multiplies input values and returns multiplication result
@@ -443,4 +583,30 @@ var (
 		}
 		return []float64{min}
 	}
+	// Computes the softmax distribution over the inputs: exp((x_i - max)/t)/sum(exp((x_j - max)/t)), shifting by the
+	// maximal input to avoid overflow. The temperature t is read from aux_params[0], defaulting to 1.0, so evolved
+	// networks can sharpen (t < 1) or soften (t > 1) the resulting distribution.
+	softmaxModule = func(inputs []float64, aux_params[]float64) []float64 {
+		if len(inputs) == 0 {
+			return []float64{}
+		}
+		temperature := 1.0
+		if len(aux_params) > 0 && aux_params[0] != 0.0 {
+			temperature = aux_params[0]
+		}
+		max := float64(math.MinInt64)
+		for _, v := range inputs {
+			max = math.Max(max, v)
+		}
+		outputs := make([]float64, len(inputs))
+		sum := 0.0
+		for i, v := range inputs {
+			outputs[i] = math.Exp((v - max) / temperature)
+			sum += outputs[i]
+		}
+		for i := range outputs {
+			outputs[i] /= sum
+		}
+		return outputs
+	}
 )
@@ -0,0 +1,152 @@
+package network
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewActivationFunctions(t *testing.T) {
+	const tol = 1e-9
+	cases := []struct {
+		name     string
+		aType    NodeActivationType
+		input    float64
+		aux      []float64
+		expected float64
+	}{
+		{"ReLU negative", ReLUActivation, -3.0, nil, 0.0},
+		{"ReLU positive", ReLUActivation, 3.0, nil, 3.0},
+		{"LeakyReLU default alpha", LeakyReLUActivation, -2.0, nil, -0.02},
+		{"LeakyReLU custom alpha", LeakyReLUActivation, -2.0, []float64{0.2}, -0.4},
+		{"LeakyReLU positive", LeakyReLUActivation, 2.0, []float64{0.2}, 2.0},
+		{"ELU positive passthrough", ELUActivation, 2.0, nil, 2.0},
+		{"ELU at zero", ELUActivation, 0.0, nil, 0.0},
+		{"ELU negative default alpha", ELUActivation, -1.0, nil, math.Exp(-1.0) - 1.0},
+		{"ELU negative custom alpha", ELUActivation, -1.0, []float64{2.0}, 2.0 * (math.Exp(-1.0) - 1.0)},
+		{"SELU positive", SELUActivation, 2.0, nil, 1.0507 * 2.0},
+		{"SELU negative", SELUActivation, -1.0, nil, 1.0507 * 1.6733 * (math.Exp(-1.0) - 1.0)},
+		{"Softplus at zero", SoftplusActivation, 0.0, nil, math.Log(2.0)},
+		{"Softsign positive", SoftsignActivation, 1.0, nil, 0.5},
+		{"Softsign negative", SoftsignActivation, -1.0, nil, -0.5},
+		{"Swish at zero", SwishActivation, 0.0, nil, 0.0},
+		{"Mish at zero", MishActivation, 0.0, nil, 0.0},
+		{"LogSigmoid at zero", LogSigmoidActivation, 0.0, nil, -math.Log(2.0)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, err := NodeActivators.ActivateByType(c.input, c.aux, c.aType)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(out-c.expected) > tol {
+				t.Errorf("expected %v, got %v", c.expected, out)
+			}
+		})
+	}
+}
+
+func TestSoftplusSymmetry(t *testing.T) {
+	// softplus(x) - softplus(-x) == x for any x, since log(1+e^x) - log(1+e^-x) == x
+	for _, x := range []float64{-5.0, -1.0, 0.0, 1.0, 5.0} {
+		pos, _ := NodeActivators.ActivateByType(x, nil, SoftplusActivation)
+		neg, _ := NodeActivators.ActivateByType(-x, nil, SoftplusActivation)
+		if math.Abs((pos-neg)-x) > 1e-9 {
+			t.Errorf("softplus(%v) - softplus(%v) = %v, want %v", x, -x, pos-neg, x)
+		}
+	}
+}
+
+func TestLogSigmoidMatchesSigmoidLog(t *testing.T) {
+	// -softplus(-x) must equal log(sigmoid(x))
+	for _, x := range []float64{-3.0, -0.5, 0.0, 0.5, 3.0} {
+		logSig, _ := NodeActivators.ActivateByType(x, nil, LogSigmoidActivation)
+		sig, _ := NodeActivators.ActivateByType(x, nil, SigmoidPlainActivation)
+		if math.Abs(logSig-math.Log(sig)) > 1e-9 {
+			t.Errorf("LogSigmoidActivation(%v) = %v, want log(sigmoid(x)) = %v", x, logSig, math.Log(sig))
+		}
+	}
+}
+
+func TestSoftmaxModuleActivation(t *testing.T) {
+	inputs := []float64{1.0, 2.0, 3.0}
+	outputs, err := NodeActivators.ActivateModuleByType(inputs, nil, SoftmaxModuleActivation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(outputs) != len(inputs) {
+		t.Fatalf("expected %d outputs, got %d", len(inputs), len(outputs))
+	}
+	sum := 0.0
+	for i, o := range outputs {
+		if o <= 0.0 || o >= 1.0 {
+			t.Errorf("output[%d] = %v, want a value strictly within (0, 1)", i, o)
+		}
+		sum += o
+	}
+	if math.Abs(sum-1.0) > 1e-9 {
+		t.Errorf("softmax outputs should sum to 1.0, got %v", sum)
+	}
+	maxIdx := 0
+	for i, o := range outputs {
+		if o > outputs[maxIdx] {
+			maxIdx = i
+		}
+	}
+	if maxIdx != len(inputs)-1 {
+		t.Errorf("expected largest input (index %d) to have the largest probability, got index %d", len(inputs)-1, maxIdx)
+	}
+}
+
+func TestSoftmaxModuleActivationTemperature(t *testing.T) {
+	inputs := []float64{1.0, 2.0, 3.0}
+	sharp, err := NodeActivators.ActivateModuleByType(inputs, []float64{0.1}, SoftmaxModuleActivation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	soft, err := NodeActivators.ActivateModuleByType(inputs, []float64{10.0}, SoftmaxModuleActivation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// a low temperature should sharpen the distribution (higher top probability) relative to a high temperature
+	if sharp[2] <= soft[2] {
+		t.Errorf("expected low-temperature softmax (%v) to sharpen the top probability beyond high-temperature (%v)",
+			sharp[2], soft[2])
+	}
+}
+
+func TestSoftmaxModuleActivationOverflowSafety(t *testing.T) {
+	// without the max-shift this would overflow to +Inf/NaN
+	inputs := []float64{1000.0, 1000.0, 1000.0}
+	outputs, err := NodeActivators.ActivateModuleByType(inputs, nil, SoftmaxModuleActivation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, o := range outputs {
+		if math.IsNaN(o) || math.IsInf(o, 0) {
+			t.Fatalf("output[%d] = %v, want a finite value", i, o)
+		}
+		if math.Abs(o-1.0/3.0) > 1e-9 {
+			t.Errorf("expected a uniform distribution for equal inputs, got output[%d] = %v", i, o)
+		}
+	}
+}
+
+func TestNewActivationTypeNameRoundTrip(t *testing.T) {
+	names := []string{
+		"ReLUActivation", "LeakyReLUActivation", "ELUActivation", "SELUActivation",
+		"SoftplusActivation", "SoftsignActivation", "SwishActivation", "MishActivation", "LogSigmoidActivation",
+	}
+	for _, name := range names {
+		aType, err := NodeActivators.ActivationTypeFromName(name)
+		if err != nil {
+			t.Fatalf("ActivationTypeFromName(%q) failed: %v", name, err)
+		}
+		gotName, err := NodeActivators.ActivationNameFromType(aType)
+		if err != nil {
+			t.Fatalf("ActivationNameFromType(%v) failed: %v", aType, err)
+		}
+		if gotName != name {
+			t.Errorf("round trip mismatch: %q -> %v -> %q", name, aType, gotName)
+		}
+	}
+}
@@ -0,0 +1,604 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/evilbros/goNEAT/neat/network/loss"
+)
+
+// FastNetworkLink describes a single connection between two neurons addressed by their index in the solver's flat
+// neuron list.
+type FastNetworkLink struct {
+	// The index of the source (input) neuron
+	SourceIndex int
+	// The index of the target (output) neuron
+	TargetIndex int
+	// The connection weight
+	Weight float64
+	// Whether this link closes a cycle within the network
+	Recurrent bool
+}
+
+// FastControlNode models a module (control) node: a node whose activation function takes several input values and
+// produces several output values in one call, dispatched through NodeActivators.ActivateModuleByType - mirroring
+// the Incoming/Outgoing module pattern used by NNode/ActivateModule. InputIndexes/OutputIndexes are plain neuron
+// indices rather than FastNetworkLink endpoints, since a module's output values are assigned directly to its output
+// neurons instead of being accumulated through a weighted sum like a regular neuron's ActivationSum.
+type FastControlNode struct {
+	// The activation function dispatched via ActivateModuleByType
+	ActivationType NodeActivationType
+	// The auxiliary parameters passed to the activation function, e.g. softmax temperature
+	Params []float64
+	// The indices of the neurons whose activation feeds the module, in order
+	InputIndexes []int
+	// The indices of the neurons the module's outputs are assigned to, in order
+	OutputIndexes []int
+}
+
+// Compile-time check that FastModularNetworkSolver implements the full NetworkSolver contract
+var _ NetworkSolver = (*FastModularNetworkSolver)(nil)
+
+// FastModularNetworkSolver is a concrete, flat-array NetworkSolver implementation. Every neuron's activation state -
+// ActivationSum, activation and isActive - is widened into per-lane slices, so that LoadSensorsBatch lets a single
+// genome/phenotype be evaluated against N input patterns at once: ForwardStepsBatch/RecursiveStepsBatch propagate
+// every lane side by side across a worker pool sized to GOMAXPROCS, instead of looping the scalar API and
+// re-flushing between patterns. The scalar methods (LoadSensors, ForwardSteps, ...) are trivial wrappers that
+// operate on lane 0.
+//
+// Neurons are addressed by a flat index: bias neurons first, then input neurons, then hidden and output neurons,
+// matching the order LoadSensors/LoadSensorsBatch and ReadOutputs/ReadOutputsBatch expect. Module (control) node
+// outputs are likewise plain neurons in this index space, but their value always comes from their owning
+// FastControlNode rather than from a weighted sum of FastNetworkLinks.
+type FastModularNetworkSolver struct {
+	// Human readable id/name, for diagnostics purposes only
+	Id   int
+	Name string
+
+	biasNeuronCount   int
+	inputNeuronCount  int
+	sensorNeuronCount int // bias + input
+	outputNeuronCount int
+	totalNeuronCount  int
+
+	// The activation function and its auxiliary parameters per neuron, indexed by neuron index. Unused for neurons
+	// that are the output of a control node, since those are activated through that node's ActivateModuleByType call
+	// instead.
+	activationFunctions []NodeActivationType
+	neuronParams        [][]float64
+
+	// The incoming links of each neuron, indexed by neuron index
+	incoming [][]*FastNetworkLink
+	links    []*FastNetworkLink
+
+	// The module (control) nodes of the network, and - indexed by neuron index - which control node owns a given
+	// neuron as one of its outputs, if any
+	controlNodes      []*FastControlNode
+	moduleOutputOwner []*FastControlNode
+
+	// Per-lane neuron state: the outer index is the neuron index, the inner index is the lane. Lane 0 backs the
+	// scalar NetworkSolver methods.
+	activationSum [][]float64
+	activation    [][]float64
+	isActive      [][]bool
+
+	// True while a lane still holds its initial (flushed) state, i.e. no hidden/output neuron has activated yet.
+	// Sized to laneCapacity.
+	fresh []bool
+
+	// The number of lanes currently loaded by LoadSensors/LoadSensorsBatch - every batch method operates on exactly
+	// this many lanes, so a LoadSensorsBatch call with fewer patterns than a previous call shrinks the set of lanes
+	// that ForwardStepsBatch/RecursiveStepsBatch/ReadOutputsBatch see, instead of leaving stale trailing lanes
+	// visible to callers.
+	laneCount int
+	// The width the per-lane slices above are actually allocated to, i.e. the largest lane count ever requested.
+	// laneCapacity only ever grows; laneCount may grow or shrink underneath it as LoadSensorsBatch is called with
+	// differently sized batches.
+	laneCapacity int
+}
+
+// NewFastModularNetworkSolver creates a new fast modular network solver for a network with given number of
+// bias/input/output/total neurons, the activation function and auxiliary parameters of every neuron (indexed the
+// same way), the flat list of connections between them, and its module (control) nodes, if any.
+func NewFastModularNetworkSolver(biasNeuronCount, inputNeuronCount, outputNeuronCount, totalNeuronCount int,
+	activationFunctions []NodeActivationType, neuronParams [][]float64, links []*FastNetworkLink,
+	controlNodes []*FastControlNode) *FastModularNetworkSolver {
+
+	s := &FastModularNetworkSolver{
+		biasNeuronCount:     biasNeuronCount,
+		inputNeuronCount:    inputNeuronCount,
+		sensorNeuronCount:   biasNeuronCount + inputNeuronCount,
+		outputNeuronCount:   outputNeuronCount,
+		totalNeuronCount:    totalNeuronCount,
+		activationFunctions: activationFunctions,
+		neuronParams:        neuronParams,
+		links:               links,
+		controlNodes:        controlNodes,
+		incoming:            make([][]*FastNetworkLink, totalNeuronCount),
+		moduleOutputOwner:   make([]*FastControlNode, totalNeuronCount),
+	}
+	for _, l := range links {
+		s.incoming[l.TargetIndex] = append(s.incoming[l.TargetIndex], l)
+	}
+	for _, cn := range controlNodes {
+		for _, idx := range cn.OutputIndexes {
+			s.moduleOutputOwner[idx] = cn
+		}
+	}
+	// lane 0 is always considered loaded, so the scalar API (Flush, ForwardSteps, ...) works before any sensors
+	// have ever been loaded, matching the usual Flush-then-LoadSensors-then-step usage pattern
+	s.growCapacity(1)
+	s.laneCount = 1
+	return s
+}
+
+// NodeCount returns the total number of neural units in the network
+func (s *FastModularNetworkSolver) NodeCount() int {
+	return s.totalNeuronCount
+}
+
+// LinkCount returns the total number of links between nodes in the network
+func (s *FastModularNetworkSolver) LinkCount() int {
+	return len(s.links)
+}
+
+// Flush flushes network state of lane 0 by removing all current activations
+func (s *FastModularNetworkSolver) Flush() (bool, error) {
+	return s.FlushLane(0)
+}
+
+// FlushLane flushes network state for the given lane only, leaving the other lanes untouched. Bias/input neuron
+// values are left in place since they are externally driven by LoadSensors/LoadSensorsBatch, not by Flush.
+func (s *FastModularNetworkSolver) FlushLane(lane int) (bool, error) {
+	if lane < 0 || lane >= s.laneCount {
+		return false, NetErrUnsupportedSensorsArraySize
+	}
+	for i := s.sensorNeuronCount; i < s.totalNeuronCount; i++ {
+		s.activationSum[i][lane] = 0.0
+		s.activation[i][lane] = 0.0
+		s.isActive[i][lane] = false
+	}
+	s.fresh[lane] = true
+	return true, nil
+}
+
+// Fresh returns true if the given lane still holds its initial (flushed) state, i.e. no hidden/output neuron has
+// activated yet
+func (s *FastModularNetworkSolver) Fresh(lane int) bool {
+	if lane < 0 || lane >= s.laneCount {
+		return false
+	}
+	return s.fresh[lane]
+}
+
+// LoadSensors sets sensor values to the input nodes of the network, on lane 0
+func (s *FastModularNetworkSolver) LoadSensors(inputs []float64) error {
+	return s.LoadSensorsBatch([][]float64{inputs})
+}
+
+// LoadSensorsBatch loads N sensor patterns at once, one per lane, growing the per-lane state to N lanes as needed.
+// Each pattern must either provide a value for every input neuron (bias neurons are then driven to 1.0 implicitly)
+// or a value for every bias+input neuron. Exactly N lanes are considered loaded afterwards - calling this again
+// with fewer patterns than a previous call shrinks the active lane count back down, so stale data left over in
+// previously loaded trailing lanes is not visible to ForwardStepsBatch/RecursiveStepsBatch/ReadOutputsBatch while
+// the lane count is smaller. A later call with more patterns loads new sensor values into those lanes but leaves
+// their hidden/output state as it was; FlushLane resets it.
+func (s *FastModularNetworkSolver) LoadSensorsBatch(inputs [][]float64) error {
+	n := len(inputs)
+	if n == 0 {
+		return NetErrUnsupportedSensorsArraySize
+	}
+	s.growCapacity(n)
+	for lane, pattern := range inputs {
+		switch len(pattern) {
+		case s.inputNeuronCount:
+			for i := 0; i < s.biasNeuronCount; i++ {
+				s.activation[i][lane] = 1.0
+				s.isActive[i][lane] = true
+			}
+			for i, v := range pattern {
+				idx := s.biasNeuronCount + i
+				s.activation[idx][lane] = v
+				s.isActive[idx][lane] = true
+			}
+		case s.sensorNeuronCount:
+			for i, v := range pattern {
+				s.activation[i][lane] = v
+				s.isActive[i][lane] = true
+			}
+		default:
+			return NetErrUnsupportedSensorsArraySize
+		}
+	}
+	s.laneCount = n
+	return nil
+}
+
+// ReadOutputs reads output values from the output nodes of the network, on lane 0
+func (s *FastModularNetworkSolver) ReadOutputs() []float64 {
+	return s.laneOutputs(0)
+}
+
+// ReadOutputsBatch reads output values from the output nodes of the network for every currently loaded lane, in
+// the same order as the patterns passed to the most recent LoadSensorsBatch call
+func (s *FastModularNetworkSolver) ReadOutputsBatch() [][]float64 {
+	outputs := make([][]float64, s.laneCount)
+	for lane := 0; lane < s.laneCount; lane++ {
+		outputs[lane] = s.laneOutputs(lane)
+	}
+	return outputs
+}
+
+// ForwardSteps propagates activation wave through all network nodes of lane 0, provided number of steps in forward
+// direction. Returns true if activation wave passed from all inputs to outputs.
+func (s *FastModularNetworkSolver) ForwardSteps(steps int) (bool, error) {
+	if steps <= 0 {
+		return false, NetErrInvalidActivationStepsNumber
+	}
+	for step := 0; step < steps; step++ {
+		if err := s.stepLane(0); err != nil {
+			return false, err
+		}
+	}
+	return s.outputsActiveLane(0), nil
+}
+
+// ForwardStepsBatch propagates activation wave through all network nodes for every currently loaded lane, given
+// number of steps in forward direction, running every lane concurrently across a worker pool sized to GOMAXPROCS.
+// Returns true if activation wave passed from all inputs to outputs in every lane, and a distinct
+// NetErrInvalidActivationStepsNumber if steps is not positive.
+func (s *FastModularNetworkSolver) ForwardStepsBatch(steps int) (bool, error) {
+	if steps <= 0 {
+		return false, NetErrInvalidActivationStepsNumber
+	}
+	results := make([]bool, s.laneCount)
+	errs := make([]error, s.laneCount)
+	s.forEachLane(func(lane int) {
+		for step := 0; step < steps; step++ {
+			if err := s.stepLane(lane); err != nil {
+				errs[lane] = err
+				return
+			}
+		}
+		results[lane] = s.outputsActiveLane(lane)
+	})
+	if err := firstError(errs); err != nil {
+		return false, err
+	}
+	return allTrue(results), nil
+}
+
+// RecursiveSteps propagates activation wave through all network nodes of lane 0 by recursion from output nodes.
+// Returns true if activation wave passed from all inputs to outputs.
+func (s *FastModularNetworkSolver) RecursiveSteps() (bool, error) {
+	visited := make([]bool, s.totalNeuronCount)
+	for i := s.totalNeuronCount - s.outputNeuronCount; i < s.totalNeuronCount; i++ {
+		if err := s.recursiveActivateLane(0, i, visited); err != nil {
+			return false, err
+		}
+	}
+	return s.outputsActiveLane(0), nil
+}
+
+// RecursiveStepsBatch propagates activation wave through all network nodes for every currently loaded lane by
+// recursion from output nodes, running every lane concurrently across a worker pool sized to GOMAXPROCS. Returns
+// true if activation wave passed from all inputs to outputs in every lane.
+func (s *FastModularNetworkSolver) RecursiveStepsBatch() (bool, error) {
+	results := make([]bool, s.laneCount)
+	errs := make([]error, s.laneCount)
+	s.forEachLane(func(lane int) {
+		visited := make([]bool, s.totalNeuronCount)
+		for i := s.totalNeuronCount - s.outputNeuronCount; i < s.totalNeuronCount; i++ {
+			if err := s.recursiveActivateLane(lane, i, visited); err != nil {
+				errs[lane] = err
+				return
+			}
+		}
+		results[lane] = s.outputsActiveLane(lane)
+	})
+	if err := firstError(errs); err != nil {
+		return false, err
+	}
+	return allTrue(results), nil
+}
+
+// snapshotStabilizationEpsilon is the convergence threshold used by SnapshotSteps: once the largest absolute change
+// of any output across a single pass drops below it, the network is considered stabilized
+const snapshotStabilizationEpsilon = 1e-6
+
+// SnapshotSteps treats the network as a pure, stateless function of its currently loaded sensor values on lane 0,
+// unlike ForwardSteps/RecursiveSteps whose result depends on whatever activation state was left over from previous
+// calls. It first flushes lane 0 (clearing leftover hidden/output activations while leaving the loaded sensor
+// values untouched), then repeatedly runs a single forward pass via stepLane - which computes every regular
+// neuron's new ActivationSum from the pre-pass activations into a local shadow buffer before committing it. Module
+// (control) nodes are not shadow-buffered: they are dispatched after that commit and read the regular neuron
+// values of the same pass. It stops as soon as every output neuron is active and the largest |delta| of any output across
+// a pass falls below snapshotStabilizationEpsilon, or after maxSteps passes. Returns false if the network failed to
+// stabilize in time. This is what makes CPPN queries deterministic and independent of previous inputs, which
+// HyperNEAT-style substrate queries rely on.
+func (s *FastModularNetworkSolver) SnapshotSteps(maxSteps int) (bool, error) {
+	if _, err := s.FlushLane(0); err != nil {
+		return false, err
+	}
+	for step := 0; step < maxSteps; step++ {
+		prev := s.laneOutputs(0)
+		if err := s.stepLane(0); err != nil {
+			return false, err
+		}
+		if s.outputsActiveLane(0) && maxAbsDelta(prev, s.laneOutputs(0)) < snapshotStabilizationEpsilon {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Relax attempts to relax lane 0's network given amount of steps until giving up. The network is considered relaxed
+// when the absolute value of the change at any output is less than maxAllowedSignalDelta between two consecutive
+// forward passes. If maxAllowedSignalDelta is less than or equal to 0, returns true without checking for relaxation.
+func (s *FastModularNetworkSolver) Relax(maxSteps int, maxAllowedSignalDelta float64) (bool, error) {
+	if maxAllowedSignalDelta <= 0 {
+		return true, nil
+	}
+	for step := 0; step < maxSteps; step++ {
+		prev := s.laneOutputs(0)
+		if err := s.stepLane(0); err != nil {
+			return false, err
+		}
+		if s.outputsActiveLane(0) && maxAbsDelta(prev, s.laneOutputs(0)) < maxAllowedSignalDelta {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// EvaluateLoss runs the network once per input/target pair - using the batched lanes from LoadSensorsBatch so every
+// sample propagates concurrently rather than looping the scalar API - and reduces the configured loss function over
+// all samples via arithmetic mean.
+func (s *FastModularNetworkSolver) EvaluateLoss(inputs, targets [][]float64, lossFn loss.LossFunction) (float64, error) {
+	if len(inputs) == 0 || len(inputs) != len(targets) {
+		return 0.0, errors.New("inputs and targets must be non-empty and of equal length")
+	}
+	if err := s.LoadSensorsBatch(inputs); err != nil {
+		return 0.0, err
+	}
+	if _, err := s.ForwardStepsBatch(s.totalNeuronCount); err != nil {
+		return 0.0, err
+	}
+	predicted := s.ReadOutputsBatch()
+	sum := 0.0
+	for i, target := range targets {
+		sum += lossFn(predicted[i], target, nil)
+	}
+	return sum / float64(len(targets)), nil
+}
+
+// growCapacity grows the per-lane neuron state to allocate at least n lanes, preserving already allocated lanes.
+// It only ever widens laneCapacity - callers that want fewer lanes considered active should adjust laneCount
+// instead, which is what LoadSensorsBatch does.
+func (s *FastModularNetworkSolver) growCapacity(n int) {
+	if n <= s.laneCapacity {
+		return
+	}
+	if s.activationSum == nil {
+		s.activationSum = make([][]float64, s.totalNeuronCount)
+		s.activation = make([][]float64, s.totalNeuronCount)
+		s.isActive = make([][]bool, s.totalNeuronCount)
+	}
+	added := n - s.laneCapacity
+	for i := 0; i < s.totalNeuronCount; i++ {
+		s.activationSum[i] = append(s.activationSum[i], make([]float64, added)...)
+		s.activation[i] = append(s.activation[i], make([]float64, added)...)
+		s.isActive[i] = append(s.isActive[i], make([]bool, added)...)
+	}
+	freshLanes := make([]bool, added)
+	for i := range freshLanes {
+		freshLanes[i] = true
+	}
+	s.fresh = append(s.fresh, freshLanes...)
+	s.laneCapacity = n
+}
+
+// stepLane runs a single forward activation pass for the given lane: every regular non-sensor neuron's new
+// ActivationSum is accumulated from the current (pre-pass) activation of its incoming neurons into a local shadow
+// buffer, which is only then used to compute and commit the new activation/isActive values; module (control) node
+// outputs are skipped by this pass and instead assigned afterwards by activateControlNodesLane. Sensor neurons are
+// externally driven and are never recomputed here.
+func (s *FastModularNetworkSolver) stepLane(lane int) error {
+	newSum := make([]float64, s.totalNeuronCount)
+	for i := s.sensorNeuronCount; i < s.totalNeuronCount; i++ {
+		if s.moduleOutputOwner[i] != nil {
+			continue
+		}
+		sum := 0.0
+		for _, link := range s.incoming[i] {
+			sum += s.activation[link.SourceIndex][lane] * link.Weight
+		}
+		newSum[i] = sum
+	}
+	for i := s.sensorNeuronCount; i < s.totalNeuronCount; i++ {
+		if s.moduleOutputOwner[i] != nil {
+			continue
+		}
+		s.activationSum[i][lane] = newSum[i]
+		out, err := NodeActivators.ActivateByType(newSum[i], s.neuronParams[i], s.activationFunctions[i])
+		if err != nil {
+			return err
+		}
+		s.activation[i][lane] = out
+		s.isActive[i][lane] = true
+	}
+	if err := s.activateControlNodesLane(lane); err != nil {
+		return err
+	}
+	s.fresh[lane] = false
+	return nil
+}
+
+// activateControlNodesLane dispatches every module (control) node of the network for the given lane, in
+// declaration order, reading each module's inputs from the already-updated regular neuron activations of this pass
+func (s *FastModularNetworkSolver) activateControlNodesLane(lane int) error {
+	for _, cn := range s.controlNodes {
+		if err := s.activateControlNode(lane, cn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// activateControlNode reads given control node's inputs from the given lane, dispatches them through
+// ActivateModuleByType, and assigns the results to the node's output neurons on that lane
+func (s *FastModularNetworkSolver) activateControlNode(lane int, cn *FastControlNode) error {
+	inputs := make([]float64, len(cn.InputIndexes))
+	for i, idx := range cn.InputIndexes {
+		inputs[i] = s.activation[idx][lane]
+	}
+	outputs, err := NodeActivators.ActivateModuleByType(inputs, cn.Params, cn.ActivationType)
+	if err != nil {
+		return err
+	}
+	if len(outputs) != len(cn.OutputIndexes) {
+		return errors.New(fmt.Sprintf(
+			"the number of output values [%d] returned by module activator doesn't match the number of output "+
+				"neurons of the module [%d]", len(outputs), len(cn.OutputIndexes)))
+	}
+	for i, idx := range cn.OutputIndexes {
+		s.activation[idx][lane] = outputs[i]
+		s.isActive[idx][lane] = true
+	}
+	return nil
+}
+
+// recursiveActivateLane activates given neuron for given lane by first recursively activating all of its
+// non-recurrent dependencies, guarding against cycles with the visited set. If the neuron is the output of a
+// control node, its owning module is activated instead of treating it as a regular weighted-sum neuron, after
+// recursively activating that module's own inputs.
+func (s *FastModularNetworkSolver) recursiveActivateLane(lane, node int, visited []bool) error {
+	if visited[node] || node < s.sensorNeuronCount {
+		return nil
+	}
+	visited[node] = true
+
+	if cn := s.moduleOutputOwner[node]; cn != nil {
+		for _, idx := range cn.InputIndexes {
+			if err := s.recursiveActivateLane(lane, idx, visited); err != nil {
+				return err
+			}
+		}
+		if err := s.activateControlNode(lane, cn); err != nil {
+			return err
+		}
+		for _, idx := range cn.OutputIndexes {
+			visited[idx] = true
+		}
+		s.fresh[lane] = false
+		return nil
+	}
+
+	for _, link := range s.incoming[node] {
+		if !link.Recurrent {
+			if err := s.recursiveActivateLane(lane, link.SourceIndex, visited); err != nil {
+				return err
+			}
+		}
+	}
+	sum := 0.0
+	for _, link := range s.incoming[node] {
+		sum += s.activation[link.SourceIndex][lane] * link.Weight
+	}
+	s.activationSum[node][lane] = sum
+	out, err := NodeActivators.ActivateByType(sum, s.neuronParams[node], s.activationFunctions[node])
+	if err != nil {
+		return err
+	}
+	s.activation[node][lane] = out
+	s.isActive[node][lane] = true
+	s.fresh[lane] = false
+	return nil
+}
+
+// outputsActiveLane returns true if every output neuron of the given lane is active
+func (s *FastModularNetworkSolver) outputsActiveLane(lane int) bool {
+	start := s.totalNeuronCount - s.outputNeuronCount
+	for i := start; i < s.totalNeuronCount; i++ {
+		if !s.isActive[i][lane] {
+			return false
+		}
+	}
+	return true
+}
+
+// laneOutputs returns a copy of the current output neuron activations of the given lane
+func (s *FastModularNetworkSolver) laneOutputs(lane int) []float64 {
+	start := s.totalNeuronCount - s.outputNeuronCount
+	out := make([]float64, s.outputNeuronCount)
+	for i := 0; i < s.outputNeuronCount; i++ {
+		out[i] = s.activation[start+i][lane]
+	}
+	return out
+}
+
+// forEachLane runs fn once per currently loaded lane, distributing the work across a worker pool sized to
+// GOMAXPROCS so that batched lanes are evaluated concurrently rather than one after another
+func (s *FastModularNetworkSolver) forEachLane(fn func(lane int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > s.laneCount {
+		workers = s.laneCount
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	lanes := make(chan int, s.laneCount)
+	for lane := 0; lane < s.laneCount; lane++ {
+		lanes <- lane
+	}
+	close(lanes)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for lane := range lanes {
+				fn(lane)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// allTrue returns true if every element of vals is true
+func allTrue(vals []bool) bool {
+	for _, v := range vals {
+		if !v {
+			return false
+		}
+	}
+	return true
+}
+
+// firstError returns the first non-nil error in errs, or nil if there is none
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxAbsDelta returns the largest absolute difference between corresponding elements of a and b
+func maxAbsDelta(a, b []float64) float64 {
+	max := 0.0
+	for i := range a {
+		d := a[i] - b[i]
+		if d < 0 {
+			d = -d
+		}
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
@@ -0,0 +1,155 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/evilbros/goNEAT/neat/network/loss"
+)
+
+// newLinearSolver builds a minimal 1-bias/2-input/1-output solver with LinearActivation throughout and
+// weight 1.0 on every link, so the output simply sums the two inputs - handy for exercising the batch plumbing
+// without involving any nonlinearity.
+func newLinearSolver() *FastModularNetworkSolver {
+	// neuron indices: 0 = bias, 1,2 = inputs, 3 = output
+	activationFunctions := []NodeActivationType{LinearActivation, LinearActivation, LinearActivation, LinearActivation}
+	neuronParams := make([][]float64, 4)
+	links := []*FastNetworkLink{
+		{SourceIndex: 1, TargetIndex: 3, Weight: 1.0},
+		{SourceIndex: 2, TargetIndex: 3, Weight: 1.0},
+	}
+	return NewFastModularNetworkSolver(1, 2, 1, 4, activationFunctions, neuronParams, links, nil)
+}
+
+func TestFastModularNetworkSolverBatchRoundTrip(t *testing.T) {
+	s := newLinearSolver()
+
+	if err := s.LoadSensorsBatch([][]float64{{1.0, 2.0}, {3.0, 4.0}, {5.0, 6.0}}); err != nil {
+		t.Fatalf("LoadSensorsBatch failed: %v", err)
+	}
+	if ok, err := s.ForwardStepsBatch(1); err != nil || !ok {
+		t.Fatalf("ForwardStepsBatch failed: ok=%v, err=%v", ok, err)
+	}
+	outputs := s.ReadOutputsBatch()
+	want := []float64{3.0, 7.0, 11.0}
+	if len(outputs) != len(want) {
+		t.Fatalf("expected %d lanes of output, got %d", len(want), len(outputs))
+	}
+	for i, w := range want {
+		if math.Abs(outputs[i][0]-w) > 1e-9 {
+			t.Errorf("lane %d: got %v, want %v", i, outputs[i][0], w)
+		}
+	}
+}
+
+func TestFastModularNetworkSolverBatchShrinksLaneCount(t *testing.T) {
+	s := newLinearSolver()
+
+	if err := s.LoadSensorsBatch([][]float64{{1.0, 1.0}, {2.0, 2.0}, {3.0, 3.0}}); err != nil {
+		t.Fatalf("LoadSensorsBatch failed: %v", err)
+	}
+	if _, err := s.ForwardStepsBatch(1); err != nil {
+		t.Fatalf("ForwardStepsBatch failed: %v", err)
+	}
+	if n := len(s.ReadOutputsBatch()); n != 3 {
+		t.Fatalf("expected 3 lanes after first load, got %d", n)
+	}
+
+	// loading a smaller batch afterwards must not leak stale lanes from the previous, larger call
+	if err := s.LoadSensorsBatch([][]float64{{5.0, 5.0}}); err != nil {
+		t.Fatalf("LoadSensorsBatch failed: %v", err)
+	}
+	if _, err := s.ForwardStepsBatch(1); err != nil {
+		t.Fatalf("ForwardStepsBatch failed: %v", err)
+	}
+	outputs := s.ReadOutputsBatch()
+	if len(outputs) != 1 {
+		t.Fatalf("expected 1 lane after shrinking batch, got %d", len(outputs))
+	}
+	if math.Abs(outputs[0][0]-10.0) > 1e-9 {
+		t.Errorf("got %v, want %v", outputs[0][0], 10.0)
+	}
+}
+
+func TestFastModularNetworkSolverForwardStepsBatchRejectsNonPositiveSteps(t *testing.T) {
+	s := newLinearSolver()
+	if err := s.LoadSensorsBatch([][]float64{{1.0, 2.0}}); err != nil {
+		t.Fatalf("LoadSensorsBatch failed: %v", err)
+	}
+	if _, err := s.ForwardStepsBatch(0); err != NetErrInvalidActivationStepsNumber {
+		t.Errorf("expected NetErrInvalidActivationStepsNumber, got %v", err)
+	}
+}
+
+// newSoftmaxModuleSolver builds a 1-bias/2-input solver whose two outputs are produced by a single
+// SoftmaxModuleActivation control node fed directly from the two input neurons, rather than through weighted links.
+func newSoftmaxModuleSolver() *FastModularNetworkSolver {
+	// neuron indices: 0 = bias, 1,2 = inputs, 3,4 = module outputs
+	activationFunctions := []NodeActivationType{LinearActivation, LinearActivation, LinearActivation, NullActivation, NullActivation}
+	neuronParams := make([][]float64, 5)
+	controlNodes := []*FastControlNode{
+		{
+			ActivationType: SoftmaxModuleActivation,
+			InputIndexes:   []int{1, 2},
+			OutputIndexes:  []int{3, 4},
+		},
+	}
+	return NewFastModularNetworkSolver(1, 2, 2, 5, activationFunctions, neuronParams, nil, controlNodes)
+}
+
+func TestFastModularNetworkSolverModuleNodeDispatch(t *testing.T) {
+	s := newSoftmaxModuleSolver()
+
+	if err := s.LoadSensorsBatch([][]float64{{1.0, 3.0}}); err != nil {
+		t.Fatalf("LoadSensorsBatch failed: %v", err)
+	}
+	if ok, err := s.ForwardStepsBatch(1); err != nil || !ok {
+		t.Fatalf("ForwardStepsBatch failed: ok=%v, err=%v", ok, err)
+	}
+	outputs := s.ReadOutputs()
+	if len(outputs) != 2 {
+		t.Fatalf("expected 2 outputs, got %d", len(outputs))
+	}
+	sum := outputs[0] + outputs[1]
+	if math.Abs(sum-1.0) > 1e-9 {
+		t.Errorf("softmax module outputs should sum to 1.0, got %v", sum)
+	}
+	if outputs[1] <= outputs[0] {
+		t.Errorf("expected the larger input to dominate the softmax output: got %v", outputs)
+	}
+}
+
+func TestFastModularNetworkSolverSnapshotSteps(t *testing.T) {
+	s := newLinearSolver()
+	if err := s.LoadSensors([]float64{2.0, 3.0}); err != nil {
+		t.Fatalf("LoadSensors failed: %v", err)
+	}
+	ok, err := s.SnapshotSteps(10)
+	if err != nil {
+		t.Fatalf("SnapshotSteps failed: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected SnapshotSteps to stabilize")
+	}
+	outputs := s.ReadOutputs()
+	if math.Abs(outputs[0]-5.0) > 1e-9 {
+		t.Errorf("got %v, want %v", outputs[0], 5.0)
+	}
+}
+
+func TestFastModularNetworkSolverEvaluateLoss(t *testing.T) {
+	s := newLinearSolver()
+	inputs := [][]float64{{1.0, 1.0}, {2.0, 2.0}}
+	targets := [][]float64{{2.0}, {4.0}}
+	mse, err := loss.Losses.ByType(loss.MSELoss)
+	if err != nil {
+		t.Fatalf("failed to look up MSELoss: %v", err)
+	}
+	got, err := s.EvaluateLoss(inputs, targets, mse)
+	if err != nil {
+		t.Fatalf("EvaluateLoss failed: %v", err)
+	}
+	if math.Abs(got) > 1e-9 {
+		t.Errorf("expected near-zero loss for an exact fit, got %v", got)
+	}
+}
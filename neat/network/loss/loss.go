@@ -0,0 +1,189 @@
+// The package loss provides built-in loss functions and a factory to look them up by name or type, mirroring the
+// node activator factory in the network package. It gives experiments a consistent, pluggable notion of prediction
+// error to plug into their fitness callback instead of each hand-rolling its own error term.
+package loss
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// The small constant used to avoid taking the log of zero in the cross-entropy losses
+const epsilon = 1e-12
+
+// LossType defines the type of loss function to use when scoring predictions against targets
+type LossType byte
+
+// Predefined loss function types
+const (
+	// The mean squared error
+	MSELoss LossType = iota + 1
+	// The mean absolute error
+	MAELoss
+	// The Huber loss
+	HuberLoss
+	// The binary cross-entropy loss
+	BinaryCrossEntropyLoss
+	// The categorical cross-entropy loss, assumes softmax outputs
+	CategoricalCrossEntropyLoss
+	// The hinge loss
+	HingeLoss
+	// The Kullback-Leibler divergence
+	KLDivergenceLoss
+)
+
+// LossFunction calculates the loss between predicted and target values for a single sample. The aux slice carries
+// loss-specific auxiliary parameters, e.g. delta for HuberLoss.
+type LossFunction func(predicted, target []float64, aux []float64) float64
+
+// The default loss function factory reference
+var Losses = NewLossFactory()
+
+// LossFactory provides lookup of loss functions by type or by name
+type LossFactory struct {
+	// The map of registered loss functions by type
+	losses map[LossType]LossFunction
+
+	// The forward and inverse maps of loss type and function name
+	forward map[LossType]string
+	inverse map[string]LossType
+}
+
+// Returns loss function factory initialized with the built-in loss functions
+func NewLossFactory() *LossFactory {
+	f := &LossFactory{
+		losses:  make(map[LossType]LossFunction),
+		forward: make(map[LossType]string),
+		inverse: make(map[string]LossType),
+	}
+	f.Register(MSELoss, meanSquaredError, "MSELoss")
+	f.Register(MAELoss, meanAbsoluteError, "MAELoss")
+	f.Register(HuberLoss, huber, "HuberLoss")
+	f.Register(BinaryCrossEntropyLoss, binaryCrossEntropy, "BinaryCrossEntropyLoss")
+	f.Register(CategoricalCrossEntropyLoss, categoricalCrossEntropy, "CategoricalCrossEntropyLoss")
+	f.Register(HingeLoss, hinge, "HingeLoss")
+	f.Register(KLDivergenceLoss, klDivergence, "KLDivergenceLoss")
+	return f
+}
+
+// Registers given loss function with provided type and name into the factory
+func (f *LossFactory) Register(l_type LossType, l_func LossFunction, f_name string) {
+	// store function
+	f.losses[l_type] = l_func
+	// store name<->type bi-directional mapping
+	f.forward[l_type] = f_name
+	f.inverse[f_name] = l_type
+}
+
+// Returns the loss function registered for given type
+func (f *LossFactory) ByType(l_type LossType) (LossFunction, error) {
+	if fn, ok := f.losses[l_type]; ok {
+		return fn, nil
+	} else {
+		return nil, errors.New(fmt.Sprintf("Unknown loss function type: %d", l_type))
+	}
+}
+
+// Returns the loss function registered under given name
+func (f *LossFactory) ByName(name string) (LossFunction, error) {
+	if t, ok := f.inverse[name]; ok {
+		return f.losses[t], nil
+	} else {
+		return nil, errors.New("Unsupported loss function name: " + name)
+	}
+}
+
+// Returns loss function name from given type
+func (f *LossFactory) NameByType(l_type LossType) (string, error) {
+	if n, ok := f.forward[l_type]; ok {
+		return n, nil
+	} else {
+		return "", errors.New(fmt.Sprintf("Unsupported loss function type: %d", l_type))
+	}
+}
+
+// The built-in loss functions
+var (
+	// The mean squared error: mean((p - t)^2)
+	meanSquaredError = func(predicted, target, aux []float64) float64 {
+		sum := 0.0
+		for i := range predicted {
+			d := predicted[i] - target[i]
+			sum += d * d
+		}
+		return sum / float64(len(predicted))
+	}
+	// The mean absolute error: mean(|p - t|)
+	meanAbsoluteError = func(predicted, target, aux []float64) float64 {
+		sum := 0.0
+		for i := range predicted {
+			sum += math.Abs(predicted[i] - target[i])
+		}
+		return sum / float64(len(predicted))
+	}
+	// The Huber loss: quadratic for |p - t| <= delta, linear beyond it. The delta threshold is read from aux[0],
+	// defaulting to 1.0
+	huber = func(predicted, target, aux []float64) float64 {
+		delta := 1.0
+		if len(aux) > 0 {
+			delta = aux[0]
+		}
+		sum := 0.0
+		for i := range predicted {
+			d := math.Abs(predicted[i] - target[i])
+			if d <= delta {
+				sum += 0.5 * d * d
+			} else {
+				sum += delta * (d - 0.5*delta)
+			}
+		}
+		return sum / float64(len(predicted))
+	}
+	// The binary cross-entropy loss: -mean(t*log(p+eps) + (1-t)*log(1-p+eps)), with predictions clamped into [0, 1]
+	// and eps keeping the logarithm finite
+	binaryCrossEntropy = func(predicted, target, aux []float64) float64 {
+		sum := 0.0
+		for i, t := range target {
+			p := clamp01(predicted[i])
+			sum += t*math.Log(p+epsilon) + (1-t)*math.Log(1-p+epsilon)
+		}
+		return -sum / float64(len(predicted))
+	}
+	// The categorical cross-entropy loss: -sum(t_i * log(p_i + eps)), assumes predicted is already a softmax
+	// distribution
+	categoricalCrossEntropy = func(predicted, target, aux []float64) float64 {
+		sum := 0.0
+		for i, t := range target {
+			sum += t * math.Log(predicted[i]+epsilon)
+		}
+		return -sum
+	}
+	// The hinge loss: mean(max(0, 1 - t*p)), targets are expected to be in {-1, 1}
+	hinge = func(predicted, target, aux []float64) float64 {
+		sum := 0.0
+		for i := range predicted {
+			sum += math.Max(0.0, 1.0-target[i]*predicted[i])
+		}
+		return sum / float64(len(predicted))
+	}
+	// The Kullback-Leibler divergence: sum(t_i * log((t_i + eps) / (p_i + eps)))
+	klDivergence = func(predicted, target, aux []float64) float64 {
+		sum := 0.0
+		for i, t := range target {
+			sum += t * math.Log((t+epsilon)/(predicted[i]+epsilon))
+		}
+		return sum
+	}
+)
+
+// Clamps given value into the [0, 1] closed interval
+func clamp01(v float64) float64 {
+	if v < 0.0 {
+		return 0.0
+	}
+	if v > 1.0 {
+		return 1.0
+	}
+	return v
+}
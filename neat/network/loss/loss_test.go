@@ -0,0 +1,112 @@
+package loss
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMeanSquaredError(t *testing.T) {
+	predicted := []float64{1.0, 2.0, 3.0}
+	target := []float64{1.0, 0.0, 3.0}
+	got := meanSquaredError(predicted, target, nil)
+	want := (0.0 + 4.0 + 0.0) / 3.0
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("MSE = %v, want %v", got, want)
+	}
+}
+
+func TestMeanAbsoluteError(t *testing.T) {
+	predicted := []float64{1.0, 2.0, 3.0}
+	target := []float64{1.0, 0.0, 5.0}
+	got := meanAbsoluteError(predicted, target, nil)
+	want := (0.0 + 2.0 + 2.0) / 3.0
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("MAE = %v, want %v", got, want)
+	}
+}
+
+func TestHuberLoss(t *testing.T) {
+	// within delta: behaves like 0.5*d^2
+	got := huber([]float64{0.5}, []float64{0.0}, []float64{1.0})
+	want := 0.5 * 0.5 * 0.5
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("Huber within delta = %v, want %v", got, want)
+	}
+	// beyond delta: behaves linearly
+	got = huber([]float64{3.0}, []float64{0.0}, []float64{1.0})
+	want = 1.0 * (3.0 - 0.5*1.0)
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("Huber beyond delta = %v, want %v", got, want)
+	}
+	// delta defaults to 1.0 when aux is empty
+	withDefault := huber([]float64{3.0}, []float64{0.0}, nil)
+	if math.Abs(withDefault-want) > 1e-9 {
+		t.Errorf("Huber default delta = %v, want %v", withDefault, want)
+	}
+}
+
+func TestBinaryCrossEntropy(t *testing.T) {
+	got := binaryCrossEntropy([]float64{1.0, 0.0}, []float64{1.0, 0.0}, nil)
+	if got > 1e-6 {
+		t.Errorf("expected near-zero loss for perfect predictions, got %v", got)
+	}
+	got = binaryCrossEntropy([]float64{0.0}, []float64{1.0}, nil)
+	if got < 10.0 {
+		t.Errorf("expected a large loss for a confidently wrong prediction, got %v", got)
+	}
+}
+
+func TestCategoricalCrossEntropy(t *testing.T) {
+	confident := categoricalCrossEntropy([]float64{0.0, 1.0, 0.0}, []float64{0.0, 1.0, 0.0}, nil)
+	if confident > 1e-6 {
+		t.Errorf("expected near-zero loss for a confident correct prediction, got %v", confident)
+	}
+	spread := categoricalCrossEntropy([]float64{0.34, 0.33, 0.33}, []float64{0.0, 1.0, 0.0}, nil)
+	if spread <= confident {
+		t.Errorf("expected spread prediction loss (%v) to exceed confident correct prediction loss (%v)", spread, confident)
+	}
+}
+
+func TestHingeLoss(t *testing.T) {
+	// correctly and confidently classified samples (t*p >= 1) contribute zero loss
+	got := hinge([]float64{2.0}, []float64{1.0}, nil)
+	if got != 0.0 {
+		t.Errorf("expected zero loss for a confident correct prediction, got %v", got)
+	}
+	// misclassified samples contribute 1 - t*p
+	got = hinge([]float64{-1.0}, []float64{1.0}, nil)
+	want := 2.0
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("Hinge = %v, want %v", got, want)
+	}
+}
+
+func TestKLDivergence(t *testing.T) {
+	identical := klDivergence([]float64{0.5, 0.5}, []float64{0.5, 0.5}, nil)
+	if math.Abs(identical) > 1e-9 {
+		t.Errorf("expected zero divergence for identical distributions, got %v", identical)
+	}
+	divergent := klDivergence([]float64{0.9, 0.1}, []float64{0.1, 0.9}, nil)
+	if divergent <= 0.0 {
+		t.Errorf("expected positive divergence for differing distributions, got %v", divergent)
+	}
+}
+
+func TestLossFactoryByTypeAndName(t *testing.T) {
+	types := []LossType{
+		MSELoss, MAELoss, HuberLoss, BinaryCrossEntropyLoss, CategoricalCrossEntropyLoss, HingeLoss, KLDivergenceLoss,
+	}
+	for _, lt := range types {
+		fn, err := Losses.ByType(lt)
+		if err != nil || fn == nil {
+			t.Fatalf("ByType(%v) failed: %v", lt, err)
+		}
+		name, err := Losses.NameByType(lt)
+		if err != nil {
+			t.Fatalf("NameByType(%v) failed: %v", lt, err)
+		}
+		if _, err := Losses.ByName(name); err != nil {
+			t.Errorf("ByName(%q) failed: %v", name, err)
+		}
+	}
+}